@@ -0,0 +1,62 @@
+package versioning
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+func TestClientCall(t *testing.T) {
+	router := mux.NewRouter()
+	route := greetRoutes()[0] // "/{version}/greet"
+	route.Register(router)
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	client := Client{BaseURL: server.URL, Route: route}
+
+	resp, err := client.Call(context.Background(), "v1", GreetRequestV1{FirstName: "John", LastName: "Sample"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testMessage(t, resp, "Hello John Sample!")
+
+	resp, err = client.Call(context.Background(), "v2", GreetRequest{Name: "Jane Sample"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testGreeting(t, resp, "Hello Jane Sample!")
+
+	if _, err := client.Call(context.Background(), "v3", GreetRequest{Name: "Jane Sample"}); err == nil {
+		t.Fatal("Call() with unregistered version: want error, got nil")
+	}
+}
+
+// TestClientCallHeaderOnlyRoute covers the "/greet" route greetRoutes also
+// registers, which has no {version} path segment and resolves purely from
+// the Accept-Version header.
+func TestClientCallHeaderOnlyRoute(t *testing.T) {
+	router := mux.NewRouter()
+	route := greetRoutes()[1] // "/greet"
+	route.Register(router)
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	client := Client{BaseURL: server.URL, Route: route}
+
+	resp, err := client.Call(context.Background(), "v1", GreetRequestV1{FirstName: "John", LastName: "Sample"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testMessage(t, resp, "Hello John Sample!")
+
+	resp, err = client.Call(context.Background(), "v2", GreetRequest{Name: "Jane Sample"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testGreeting(t, resp, "Hello Jane Sample!")
+}
@@ -0,0 +1,84 @@
+package versioning
+
+import "net/http"
+
+// DeprecationInfo describes why and when a request version was deprecated.
+// It is attached to a registered version and surfaced to clients as response
+// headers whenever that version is served.
+type DeprecationInfo struct {
+	// Date is the RFC 3339 date the version was deprecated.
+	Date string
+	// Sunset is the RFC 3339 date the version will stop being served,
+	// echoed back verbatim as the Sunset header.
+	Sunset string
+	// InfoURL points clients at migration documentation.
+	InfoURL string
+	// Replacement is the version clients should migrate to.
+	Replacement string
+	// Message is a short human-readable warning surfaced as X-API-Warn.
+	Message string
+}
+
+// DeprecationOption configures a DeprecationInfo built with Deprecated.
+type DeprecationOption func(*DeprecationInfo)
+
+// WithDeprecationDate sets the date a version was deprecated.
+func WithDeprecationDate(date string) DeprecationOption {
+	return func(d *DeprecationInfo) { d.Date = date }
+}
+
+// WithSunset sets the date a version will stop being served.
+func WithSunset(date string) DeprecationOption {
+	return func(d *DeprecationInfo) { d.Sunset = date }
+}
+
+// WithInfoURL sets the URL clients should consult for migration guidance.
+func WithInfoURL(url string) DeprecationOption {
+	return func(d *DeprecationInfo) { d.InfoURL = url }
+}
+
+// WithReplacement sets the version clients should migrate to.
+func WithReplacement(version string) DeprecationOption {
+	return func(d *DeprecationInfo) { d.Replacement = version }
+}
+
+// WithMessage sets the warning text surfaced as X-API-Warn.
+func WithMessage(message string) DeprecationOption {
+	return func(d *DeprecationInfo) { d.Message = message }
+}
+
+// Deprecated builds a DeprecationInfo from a set of options. It's meant to
+// be used when registering a deprecated request version, e.g.:
+//
+//	Deprecated(
+//		WithSunset("2024-07-01"),
+//		WithReplacement(currentVersion),
+//		WithMessage("GreetRequestV1 is deprecated; use the name field instead."),
+//	)
+func Deprecated(opts ...DeprecationOption) *DeprecationInfo {
+	info := &DeprecationInfo{}
+	for _, opt := range opts {
+		opt(info)
+	}
+	return info
+}
+
+// setHeaders writes the RFC-style deprecation headers for d onto w. It is a
+// no-op on a nil *DeprecationInfo, so callers can invoke it unconditionally
+// for versions that carry no deprecation metadata.
+func (d *DeprecationInfo) setHeaders(w http.ResponseWriter) {
+	if d == nil {
+		return
+	}
+
+	w.Header().Set("Deprecation", "true")
+	if d.Sunset != "" {
+		w.Header().Set("Sunset", d.Sunset)
+	}
+	if d.Message != "" {
+		w.Header().Set("X-API-Warn", d.Message)
+	}
+	if d.InfoURL != "" {
+		w.Header().Set("X-API-Deprecation-Info", d.InfoURL)
+	}
+}
@@ -0,0 +1,40 @@
+package versioning
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDeprecationInfoSetHeaders(t *testing.T) {
+	info := Deprecated(
+		WithSunset("2024-07-01"),
+		WithInfoURL("https://example.com/docs/migrating-to-v2"),
+		WithMessage("GreetRequestV1 is deprecated"),
+	)
+
+	w := httptest.NewRecorder()
+	info.setHeaders(w)
+
+	tests := map[string]string{
+		"Deprecation":            "true",
+		"Sunset":                 "2024-07-01",
+		"X-API-Warn":             "GreetRequestV1 is deprecated",
+		"X-API-Deprecation-Info": "https://example.com/docs/migrating-to-v2",
+	}
+	for header, want := range tests {
+		if got := w.Header().Get(header); got != want {
+			t.Errorf("%s = %q, want %q", header, got, want)
+		}
+	}
+}
+
+func TestNilDeprecationInfoSetHeaders(t *testing.T) {
+	var info *DeprecationInfo
+
+	w := httptest.NewRecorder()
+	info.setHeaders(w)
+
+	if got := w.Header().Get("Deprecation"); got != "" {
+		t.Errorf("Deprecation = %q, want empty", got)
+	}
+}
@@ -0,0 +1,70 @@
+package versioning
+
+import (
+	"mime"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// VersionResolver extracts a requested API version from an inbound request.
+// Implementations report ok=false when the request does not carry a version
+// in the form they understand, so callers can fall through to the next
+// resolver in a chain.
+type VersionResolver interface {
+	ResolveVersion(r *http.Request) (version string, ok bool)
+}
+
+// PathResolver resolves the version from a mux path variable, e.g. the
+// "version" in "/{version}/greet".
+type PathResolver struct {
+	Param string
+}
+
+func (p PathResolver) ResolveVersion(r *http.Request) (string, bool) {
+	v, ok := mux.Vars(r)[p.Param]
+	return v, ok && v != ""
+}
+
+// HeaderResolver resolves the version from a plain HTTP header, e.g.
+// "Accept-Version: v1".
+type HeaderResolver struct {
+	Header string
+}
+
+func (h HeaderResolver) ResolveVersion(r *http.Request) (string, bool) {
+	v := r.Header.Get(h.Header)
+	return v, v != ""
+}
+
+// AcceptResolver resolves the version from a media-type parameter on the
+// Accept header, e.g. "Accept: application/json; version=1.0".
+type AcceptResolver struct {
+	Param string
+}
+
+func (a AcceptResolver) ResolveVersion(r *http.Request) (string, bool) {
+	accept := r.Header.Get("Accept")
+	if accept == "" {
+		return "", false
+	}
+
+	_, params, err := mime.ParseMediaType(accept)
+	if err != nil {
+		return "", false
+	}
+
+	v, ok := params[a.Param]
+	return v, ok && v != ""
+}
+
+// resolveVersion tries each resolver in order and returns the first version
+// it finds.
+func resolveVersion(r *http.Request, resolvers ...VersionResolver) (string, bool) {
+	for _, resolver := range resolvers {
+		if v, ok := resolver.ResolveVersion(r); ok {
+			return v, ok
+		}
+	}
+	return "", false
+}
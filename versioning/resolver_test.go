@@ -0,0 +1,30 @@
+package versioning
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAcceptResolver(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Accept", "application/json; version=1.0")
+
+	resolver := AcceptResolver{Param: "version"}
+	v, ok := resolver.ResolveVersion(r)
+	if !ok {
+		t.Fatal("ResolveVersion() ok = false, want true")
+	}
+	if v != "1.0" {
+		t.Errorf("ResolveVersion() = %q, want %q", v, "1.0")
+	}
+}
+
+func TestAcceptResolverNoParam(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Accept", "application/json")
+
+	resolver := AcceptResolver{Param: "version"}
+	if _, ok := resolver.ResolveVersion(r); ok {
+		t.Fatal("ResolveVersion() ok = true, want false")
+	}
+}
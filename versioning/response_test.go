@@ -0,0 +1,37 @@
+package versioning
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestGreetResponseEncode(t *testing.T) {
+	resp := GreetResponse{Greeting: "Hello Jane Sample!"}
+
+	var buf bytes.Buffer
+	if err := resp.Encode("1.0.0", &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var v1 GreetResponseV1
+	if err := json.Unmarshal(buf.Bytes(), &v1); err != nil {
+		t.Fatal(err)
+	}
+	if want := "Hello Jane Sample!"; v1.Message != want {
+		t.Errorf("Message = %q, want %q", v1.Message, want)
+	}
+
+	buf.Reset()
+	if err := resp.Encode("2.0.0", &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var v2 GreetResponse
+	if err := json.Unmarshal(buf.Bytes(), &v2); err != nil {
+		t.Fatal(err)
+	}
+	if want := "Hello Jane Sample!"; v2.Greeting != want {
+		t.Errorf("Greeting = %q, want %q", v2.Greeting, want)
+	}
+}
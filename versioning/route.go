@@ -0,0 +1,194 @@
+package versioning
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+
+	"github.com/gorilla/mux"
+)
+
+// Shape marks a struct as a request shape a Route can decode. A shape's
+// fields are populated from the incoming request using its json, header,
+// url, and query struct tags — json via the standard decoder, the rest via
+// reflection in decodeShape. Shape carries no behavior of its own; it exists
+// so Route.Versions reads as a map of version to shape at the call site.
+type Shape interface{}
+
+// Converter is implemented by every non-current Shape registered on a
+// Route. It translates a decoded legacy shape into the current GreetRequest
+// shape that Route.Handler actually receives, replacing the embed-a-pointer-
+// and-hand-write-UnmarshalJSON trick GreetRequestV1 used to rely on.
+type Converter interface {
+	Convert() GreetRequest
+}
+
+// Route declares a single versioned endpoint declaratively: the method and
+// path to register, the shape expected for each version, optional
+// deprecation metadata per version, and a Handler that only ever sees the
+// current GreetRequest shape and returns the current GreetResponse shape.
+// Registering a new request version is a matter of adding an entry to
+// Versions (and, if it replaces an older shape, a Converter implementation);
+// registering a new response version is a matter of adding an entry to
+// registeredResponses (see versioning.go). Handler never changes either
+// way.
+type Route struct {
+	Method     string
+	Path       string
+	Versions   map[string]Shape
+	Deprecated map[string]*DeprecationInfo
+	Handler    func(req GreetRequest) (GreetResponse, error)
+}
+
+// Register wires the route onto router, resolving the requested version from
+// the path variable, then the Accept-Version header, then the Accept
+// media-type's version parameter.
+func (rt Route) Register(router *mux.Router) {
+	router.HandleFunc(rt.Path, rt.serve).Methods(rt.Method)
+}
+
+func (rt Route) serve(w http.ResponseWriter, r *http.Request) {
+	resolved, ok := resolveVersion(r,
+		PathResolver{Param: "version"},
+		HeaderResolver{Header: "Accept-Version"},
+		AcceptResolver{Param: "version"},
+	)
+	if !ok {
+		http.Error(w, "could not determine requested API version", 400)
+		return
+	}
+
+	label, shape, ok := rt.lookupVersion(resolved)
+	if !ok {
+		http.Error(w, fmt.Sprintf("unsupported API version %q", resolved), 400)
+		return
+	}
+
+	decoded := reflect.New(reflect.TypeOf(shape))
+	if err := decodeShape(r, decoded.Interface()); err != nil {
+		http.Error(w, err.Error(), 400)
+		return
+	}
+
+	req, err := toGreetRequest(decoded.Elem().Interface())
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+
+	served, err := canonicalVersion(label)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+
+	info := VersionInfo{Version: served, Deprecated: rt.Deprecated[label]}
+	w.Header().Set("X-API-Version", info.Version)
+	info.Deprecated.setHeaders(w)
+
+	resp, err := rt.Handler(req)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+
+	if err := resp.Encode(info.Version, w); err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+}
+
+// canonicalVersion expands a bare major-version label like "v1" into a full
+// semver for that major version, e.g. "1.0.0", so clients see a consistent
+// X-API-Version regardless of how the route author wrote the label.
+func canonicalVersion(label string) (string, error) {
+	v, err := parseSemver(label)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d.0.0", v.major), nil
+}
+
+// lookupVersion finds the Versions entry whose label's major version
+// matches resolved, e.g. label "v1" matches a resolved "1", "1.0", or
+// "1.4.2".
+func (rt Route) lookupVersion(resolved string) (label string, shape Shape, ok bool) {
+	for l, s := range rt.Versions {
+		c, err := versionConstraintForLabel(l)
+		if err != nil {
+			continue
+		}
+		if c.Matches(resolved) {
+			return l, s, true
+		}
+	}
+	return "", nil, false
+}
+
+// versionConstraintForLabel expands a bare major-version label like "v1"
+// into a range constraint covering that major version (">= 1.0, < 2.0"), so
+// Route gets range matching for free.
+func versionConstraintForLabel(label string) (*constraint, error) {
+	v, err := parseSemver(label)
+	if err != nil {
+		return nil, err
+	}
+	return parseConstraint(fmt.Sprintf(">= %d.0, < %d.0", v.major, v.major+1))
+}
+
+// toGreetRequest converts a decoded shape into the current GreetRequest. The
+// current shape is returned as-is; any other shape must implement
+// Converter.
+func toGreetRequest(shape interface{}) (GreetRequest, error) {
+	if req, ok := shape.(GreetRequest); ok {
+		return req, nil
+	}
+	if conv, ok := shape.(Converter); ok {
+		return conv.Convert(), nil
+	}
+	return GreetRequest{}, fmt.Errorf("versioning: %T does not implement Converter", shape)
+}
+
+// decodeShape populates out (a pointer to a Shape) from r: the request body
+// is JSON-decoded directly, then any string field tagged header, url, or
+// query is filled in from the matching part of the request.
+func decodeShape(r *http.Request, out interface{}) error {
+	if r.Body != nil {
+		if err := json.NewDecoder(r.Body).Decode(out); err != nil && err != io.EOF {
+			return err
+		}
+	}
+
+	v := reflect.ValueOf(out).Elem()
+	t := v.Type()
+	vars := mux.Vars(r)
+	query := r.URL.Query()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fv := v.Field(i)
+		if !fv.CanSet() || fv.Kind() != reflect.String {
+			continue
+		}
+
+		if tag, ok := field.Tag.Lookup("header"); ok {
+			if hv := r.Header.Get(tag); hv != "" {
+				fv.SetString(hv)
+			}
+		}
+		if tag, ok := field.Tag.Lookup("url"); ok {
+			if uv, ok := vars[tag]; ok {
+				fv.SetString(uv)
+			}
+		}
+		if tag, ok := field.Tag.Lookup("query"); ok {
+			if qv := query.Get(tag); qv != "" {
+				fv.SetString(qv)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package versioning
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+func TestDecodeShape(t *testing.T) {
+	type widgetShape struct {
+		Name   string `json:"name"`
+		Auth   string `header:"Authorization"`
+		ID     string `url:"id"`
+		Filter string `query:"filter"`
+	}
+
+	r := httptest.NewRequest("POST", "/widgets/42?filter=active", strings.NewReader(`{"name":"Jane"}`))
+	r.Header.Set("Authorization", "Bearer token")
+	r = mux.SetURLVars(r, map[string]string{"id": "42"})
+
+	var out widgetShape
+	if err := decodeShape(r, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	if out.Name != "Jane" {
+		t.Errorf("Name = %q, want %q", out.Name, "Jane")
+	}
+	if out.Auth != "Bearer token" {
+		t.Errorf("Auth = %q, want %q", out.Auth, "Bearer token")
+	}
+	if out.ID != "42" {
+		t.Errorf("ID = %q, want %q", out.ID, "42")
+	}
+	if out.Filter != "active" {
+		t.Errorf("Filter = %q, want %q", out.Filter, "active")
+	}
+}
+
+func TestGreetRequestV1Convert(t *testing.T) {
+	v1 := GreetRequestV1{FirstName: "John", LastName: "Sample"}
+	got := v1.Convert()
+	if want := "John Sample"; got.Name != want {
+		t.Errorf("Convert().Name = %q, want %q", got.Name, want)
+	}
+}
+
+func TestCanonicalVersion(t *testing.T) {
+	tests := map[string]string{
+		"v1":    "1.0.0",
+		"v2":    "2.0.0",
+		"1.4.2": "1.0.0",
+	}
+	for label, want := range tests {
+		got, err := canonicalVersion(label)
+		if err != nil {
+			t.Fatalf("canonicalVersion(%q) error: %v", label, err)
+		}
+		if got != want {
+			t.Errorf("canonicalVersion(%q) = %q, want %q", label, got, want)
+		}
+	}
+}
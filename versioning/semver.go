@@ -0,0 +1,150 @@
+package versioning
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// semver is a minimal, three-component (major.minor.patch) version. It has
+// no pre-release or build metadata, and parseSemver rejects versions that
+// carry either; this package only
+// needs enough of semver to compare the handful of API versions we declare
+// ourselves.
+type semver struct {
+	major, minor, patch int
+}
+
+// parseSemver parses a version string such as "1", "1.0", or "1.0.0" into a
+// semver. Missing components default to zero.
+func parseSemver(s string) (semver, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if s == "" {
+		return semver{}, fmt.Errorf("versioning: empty version")
+	}
+
+	parts := strings.SplitN(s, ".", 3)
+	nums := [3]int{}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return semver{}, fmt.Errorf("versioning: invalid version %q: %w", s, err)
+		}
+		nums[i] = n
+	}
+
+	return semver{major: nums[0], minor: nums[1], patch: nums[2]}, nil
+}
+
+// compare returns -1, 0, or 1 depending on whether v is less than, equal to,
+// or greater than other.
+func (v semver) compare(other semver) int {
+	switch {
+	case v.major != other.major:
+		return sign(v.major - other.major)
+	case v.minor != other.minor:
+		return sign(v.minor - other.minor)
+	default:
+		return sign(v.patch - other.patch)
+	}
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// constraint is a comma-separated set of semver comparisons, e.g.
+// ">= 1.0, < 2.0". A version satisfies the constraint when it satisfies every
+// clause.
+type constraint struct {
+	clauses []clause
+}
+
+type clause struct {
+	op      string
+	version semver
+}
+
+// parseConstraint parses a comma-separated list of operator/version clauses.
+// Supported operators are ==, !=, >=, <=, >, and <.
+func parseConstraint(s string) (*constraint, error) {
+	var c constraint
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		op, rest := splitOperator(part)
+		v, err := parseSemver(strings.TrimSpace(rest))
+		if err != nil {
+			return nil, fmt.Errorf("versioning: invalid constraint %q: %w", s, err)
+		}
+		c.clauses = append(c.clauses, clause{op: op, version: v})
+	}
+
+	if len(c.clauses) == 0 {
+		return nil, fmt.Errorf("versioning: invalid constraint %q", s)
+	}
+
+	return &c, nil
+}
+
+// mustParseConstraint is a helper for declaring package-level constraints
+// from literals. It panics on invalid input, which is only reachable through
+// a programming error in this package.
+func mustParseConstraint(s string) *constraint {
+	c, err := parseConstraint(s)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func splitOperator(s string) (op, rest string) {
+	for _, candidate := range []string{">=", "<=", "==", "!=", ">", "<"} {
+		if strings.HasPrefix(s, candidate) {
+			return candidate, s[len(candidate):]
+		}
+	}
+	return "==", s
+}
+
+// Matches reports whether version satisfies every clause in the constraint.
+func (c *constraint) Matches(version string) bool {
+	v, err := parseSemver(version)
+	if err != nil {
+		return false
+	}
+
+	for _, cl := range c.clauses {
+		cmp := v.compare(cl.version)
+		var ok bool
+		switch cl.op {
+		case ">=":
+			ok = cmp >= 0
+		case "<=":
+			ok = cmp <= 0
+		case ">":
+			ok = cmp > 0
+		case "<":
+			ok = cmp < 0
+		case "!=":
+			ok = cmp != 0
+		default: // "=="
+			ok = cmp == 0
+		}
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,25 @@
+package versioning
+
+import "testing"
+
+func TestConstraintMatches(t *testing.T) {
+	c := mustParseConstraint(">= 1.0, < 2.0")
+
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{"1.0.0", true},
+		{"1.5.2", true},
+		{"1", true},
+		{"2.0.0", false},
+		{"0.9.0", false},
+		{"not-a-version", false},
+	}
+
+	for _, tt := range tests {
+		if got := c.Matches(tt.version); got != tt.want {
+			t.Errorf("Matches(%q) = %v, want %v", tt.version, got, tt.want)
+		}
+	}
+}
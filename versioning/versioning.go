@@ -22,53 +22,61 @@
 // Since the underlying business logic is the same, however, we did not want to
 // deal with an explosion of handlers just to handle multiple request shapes.
 //
-// Solution
-//
-// In order to deprecate the original version but continue support, we renamed
-// the original request type from GreetRequest to GreetRequestV1. We also
-// created a new GreetRequest type with the new shape. GreetRequest will always
-// be the most current version, with older version being renamed to make their
-// deprecated status more obvious.
-//
-// We then embedded a pointer to the new GreetRequest type inside of the
-// GreetRequestV1 type. The reason for this will become obvious soon.
-//
-// With the new types in place, we updated the GreetHandler to create the
-// greeting using the single Name field of GreetRequest. At this point, we are
-// properly supporting the new version, but older clients would either need a
-// separate handler (not ideal), or our existing handler would need to be able
-// to handle multiple request shapes. Instead of shoving all of that logic into
-// the controller, however, we opted to add custom unmarshaling behavior to our
-// request types. We accomplish this in a couple ways.
-//
-// For our deprecated GreetRequestV1 type, we add an UnmarshalJSON method that
-// implements json.Unmarshaler by aliasing the type and calling json.Unmarshal
-// directly. We then take the separate first and last name fields and combine
-// them into the single name field on the embedded GreetRequest.
-//
-// For our new type, we add a few new methods. The first is a helper for
-// wrapping a GreetRequest with a GreetRequestV1. This is then used in the
-// second new method, Decode, when decoding a version 1 request shape. By
-// embedding our GreetRequest, GreetRequestV1.UnmarshalJSON can set fields
-// directly, transparently to the user. In the case we are looking at a version
-// 2 shape (indicated by the version path parameter), we can simply decode
-// directly.
-//
-// Finally, the handler is updated to call Decode directly on a GreetRequest
-// while passing in the version pulled from the path. Now we can support both
-// endpoints using a single handler. If the API needs to be updated again, we
-// simply perform the same process:
-//
-//   - Rename the deprecated version
-//   - Embed the GreetRequest pointer
-//   - Add a custom UnmarshalJSON to the deprecated version
-//   - Create the new type (note that now we'll need helpers for all supported
-//     version)
-//   - Add cases to the version switch for all supported versions using the new
-//     helpers
-//
-// Everything else from this point should work as intended and we can see
-// exactly how we're converting from one type to another without magic.
+// Version Negotiation
+//
+// Pinning the version to a path segment works, but it forces every client to
+// bake the version into the URL it calls. To let clients negotiate a version
+// some other way, we introduced the VersionResolver interface along with
+// PathResolver, HeaderResolver, and AcceptResolver implementations covering
+// the path variable, an Accept-Version header, and the version parameter on
+// a media-type Accept header, respectively. Routes try each in turn and use
+// whichever resolves first.
+//
+// Deprecation
+//
+// Declaring a version isn't enough on its own to tell clients it's going
+// away. A version can carry a *DeprecationInfo, built with Deprecated and a
+// handful of With* options, describing when the version was deprecated, when
+// it sunsets, where to read about migrating, and what replaces it. Serving a
+// deprecated version sets the Deprecation, Sunset, X-API-Warn, and
+// X-API-Deprecation-Info response headers; serving the current version sets
+// none of them.
+//
+// Declarative Routes
+//
+// The original solution renamed the deprecated request type to GreetRequestV1,
+// embedded a pointer to the new GreetRequest inside it, and gave it a custom
+// UnmarshalJSON that populated the embedded GreetRequest.Name from the
+// separate FirstName/LastName fields. GreetRequest.Decode then picked which
+// shape to decode into based on the requested version. That worked, but every
+// new deprecated version meant repeating the embed-and-UnmarshalJSON dance.
+//
+// We replaced it with Route (see route.go): a declarative description of an
+// endpoint's method, path, and the Shape registered for each version.
+// GreetRequestV1 no longer embeds GreetRequest or implements
+// json.Unmarshaler - it's a plain struct with json tags, and instead
+// implements Converter, translating itself into the current GreetRequest
+// shape. Route.Register resolves the version, decodes the request into
+// whichever Shape is registered for it (via struct tags, not hand-written
+// unmarshaling - see decodeShape in route.go), converts to GreetRequest if
+// needed, and only then calls Handler. greetBusinessLogic, our Handler, never
+// sees a version number or a deprecated shape.
+//
+// Client mirrors the same declarative description to build requests against
+// a specific version without hand-assembling URLs or payloads; see
+// client.go.
+//
+// Response Versioning
+//
+// Everything above versions the request; GreetHandler always wrote back
+// {"greeting": "..."}. We added a matching GreetResponse/GreetResponseV1
+// pair: greetBusinessLogic (our Handler) returns a single current
+// GreetResponse, and GreetResponse.Encode downgrades it to GreetResponseV1's
+// {"message": "..."} shape when the served version matches the same
+// ">= 1.0, < 2.0" constraint GreetRequestV1 registers against. Route.serve
+// calls Encode with the version it already resolved, so a v1 client keeps
+// getting the legacy response shape and adding a new response version is
+// just another entry in registeredResponses - Handler never changes.
 package versioning
 
 import (
@@ -80,65 +88,130 @@ import (
 	"github.com/gorilla/mux"
 )
 
+// currentVersion is the semver declared for the current GreetRequest shape.
+const currentVersion = "2.0.0"
+
+// VersionInfo describes the outcome of resolving a request to a served
+// version: the concrete version, and that version's deprecation metadata, if
+// any.
+type VersionInfo struct {
+	Version    string
+	Deprecated *DeprecationInfo
+}
+
+// v1Deprecation documents why GreetRequestV1 is going away and what replaces
+// it. Route looks this up by version label when serving a v1 request.
+var v1Deprecation = Deprecated(
+	WithDeprecationDate("2024-01-01"),
+	WithSunset("2024-07-01"),
+	WithInfoURL("https://example.com/docs/migrating-to-v2"),
+	WithReplacement(currentVersion),
+	WithMessage("GreetRequestV1 is deprecated; use the single name field instead."),
+)
+
 // GreetRequestV1 represents a previous version of the request shape we want to
 // support. In this version, we allowed clients to supply both a first and last
 // name.
 type GreetRequestV1 struct {
-	*GreetRequest
-
 	FirstName string `json:"firstName"`
 	LastName  string `json:"lastName"`
 }
 
-func (r GreetRequestV1) UnmarshalJSON(b []byte) error {
-	type grv1 GreetRequestV1
-	var v1r grv1
-	if err := json.Unmarshal(b, &v1r); err != nil {
-		return err
-	}
-	r.GreetRequest.Name = v1r.FirstName + " " + v1r.LastName
-	return nil
+// Convert implements Converter, combining the separate name fields into the
+// current GreetRequest shape's single Name field.
+func (v1 GreetRequestV1) Convert() GreetRequest {
+	return GreetRequest{Name: v1.FirstName + " " + v1.LastName}
 }
 
 type GreetRequest struct {
 	Name string `json:"name"`
 }
 
-func (gr *GreetRequest) fromV1() json.Unmarshaler {
-	return &GreetRequestV1{GreetRequest: gr}
+// GreetResponseV1 is the response shape a v1 client expects: a single
+// "message" field.
+type GreetResponseV1 struct {
+	Message string `json:"message"`
 }
 
-func (gr *GreetRequest) Decode(version string, r io.Reader) error {
-	dec := json.NewDecoder(r)
+// GreetResponse is the current response shape.
+type GreetResponse struct {
+	Greeting string `json:"greeting"`
+}
 
-	var err error
-	switch version {
-	case "v1":
-		err = dec.Decode(gr.fromV1())
-	default:
-		err = dec.Decode(gr)
+// registeredResponse associates a semver constraint with the downgrade
+// function used when a request was served at a version older than current.
+type registeredResponse struct {
+	constraint *constraint
+	downgrade  func(GreetResponse) interface{}
+}
+
+// registeredResponses lists every non-current response shape GreetResponse
+// knows how to downgrade to, in priority order. Encode walks this list and
+// falls back to encoding the current shape directly when nothing matches.
+// Adding a new response version is just a new entry here; Route and
+// greetBusinessLogic never change.
+var registeredResponses = []registeredResponse{
+	{
+		constraint: mustParseConstraint(">= 1.0, < 2.0"),
+		downgrade: func(gr GreetResponse) interface{} {
+			return GreetResponseV1{Message: gr.Greeting}
+		},
+	},
+}
+
+// Encode writes gr to w, downgrading to an older response shape when version
+// matches a registered constraint older than current.
+func (gr GreetResponse) Encode(version string, w io.Writer) error {
+	for _, rr := range registeredResponses {
+		if rr.constraint.Matches(version) {
+			return json.NewEncoder(w).Encode(rr.downgrade(gr))
+		}
 	}
+	return json.NewEncoder(w).Encode(gr)
+}
 
-	return err
+// greetBusinessLogic is the greeter's actual logic. It only ever sees the
+// current GreetRequest shape and produces the current GreetResponse shape;
+// Route takes care of decoding the request and encoding the response for
+// whichever version the client actually negotiated.
+func greetBusinessLogic(req GreetRequest) (GreetResponse, error) {
+	return GreetResponse{Greeting: fmt.Sprintf("Hello %s!", req.Name)}, nil
 }
 
-func GreetHandler(w http.ResponseWriter, r *http.Request) {
-	version := mux.Vars(r)["version"]
+// greetRoutes declares the greeter endpoint for both the path-versioned URLs
+// clients already use and a version-agnostic "/greet" that relies on
+// VersionResolver to negotiate via headers instead.
+func greetRoutes() []Route {
+	versions := map[string]Shape{
+		"v1": GreetRequestV1{},
+		"v2": GreetRequest{},
+	}
+	deprecated := map[string]*DeprecationInfo{
+		"v1": v1Deprecation,
+	}
 
-	var req GreetRequest
-	if err := req.Decode(version, r.Body); err != nil {
-		http.Error(w, err.Error(), 400)
-		return
+	newRoute := func(path string) Route {
+		return Route{
+			Method:     http.MethodPost,
+			Path:       path,
+			Versions:   versions,
+			Deprecated: deprecated,
+			Handler:    greetBusinessLogic,
+		}
 	}
 
-	greeting := fmt.Sprintf("Hello %s!", req.Name)
-	json.NewEncoder(w).Encode(map[string]string{"greeting": greeting})
+	return []Route{
+		newRoute("/{version}/greet"),
+		newRoute("/greet"),
+	}
 }
 
 type App struct{}
 
 func (a *App) Run() error {
 	router := mux.NewRouter()
-	router.HandleFunc("/{version}/greet", GreetHandler)
+	for _, route := range greetRoutes() {
+		route.Register(router)
+	}
 	return http.ListenAndServe(":8080", router)
 }
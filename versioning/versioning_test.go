@@ -21,7 +21,13 @@ func TestApp(t *testing.T) {
 		if err != nil {
 			t.Fatal(err)
 		}
-		testGreeting(t, resp, "Hello John Sample!")
+		testMessage(t, resp, "Hello John Sample!")
+		if v := resp.Header.Get("Deprecation"); v != "true" {
+			t.Errorf("Deprecation = %q, want %q", v, "true")
+		}
+		if v := resp.Header.Get("Sunset"); v != "2024-07-01" {
+			t.Errorf("Sunset = %q, want %q", v, "2024-07-01")
+		}
 	}
 
 	{
@@ -35,6 +41,30 @@ func TestApp(t *testing.T) {
 			t.Fatal(err)
 		}
 		testGreeting(t, resp, "Hello Jane Sample!")
+		if v := resp.Header.Get("X-API-Version"); v != "2.0.0" {
+			t.Errorf("X-API-Version = %q, want %q", v, "2.0.0")
+		}
+		if v := resp.Header.Get("Deprecation"); v != "" {
+			t.Errorf("Deprecation = %q, want empty", v)
+		}
+	}
+
+	{
+		body := `{"firstName": "John", "lastName": "Sample"}`
+		req, err := http.NewRequest(http.MethodPost, "http://localhost:8080/greet", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Accept-Version", "v1")
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		testMessage(t, resp, "Hello John Sample!")
+		if v := resp.Header.Get("X-API-Version"); v != "1.0.0" {
+			t.Errorf("X-API-Version = %q, want %q", v, "1.0.0")
+		}
 	}
 }
 
@@ -51,3 +81,18 @@ func testGreeting(t *testing.T, resp *http.Response, want string) {
 		t.Errorf("wanted greeting %q. got=%q", want, response.Greeting)
 	}
 }
+
+// testMessage checks the legacy v1 response shape, {"message": "..."}.
+func testMessage(t *testing.T, resp *http.Response, want string) {
+	var response struct {
+		Message string `json:"message"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if response.Message != want {
+		t.Errorf("wanted message %q. got=%q", want, response.Message)
+	}
+}